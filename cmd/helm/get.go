@@ -18,6 +18,7 @@ package main
 
 import (
 	"errors"
+	"fmt"
 	"io"
 
 	"github.com/spf13/cobra"
@@ -91,5 +92,8 @@ func (g *getCmd) run() error {
 	if err != nil {
 		return prettyError(err)
 	}
+	if res.Release == nil {
+		return fmt.Errorf("no release content returned for %q", g.release)
+	}
 	return printRelease(g.out, res.Release)
 }
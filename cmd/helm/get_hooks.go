@@ -73,6 +73,9 @@ func (g *getHooksCmd) run() error {
 		fmt.Fprintln(g.out, g.release)
 		return prettyError(err)
 	}
+	if res.Release == nil {
+		return fmt.Errorf("no release content returned for %q", g.release)
+	}
 
 	for _, hook := range res.Release.Hooks {
 		fmt.Fprintf(g.out, "---\n# %s\n%s\n", hook.Name, hook.Manifest)
@@ -76,6 +76,9 @@ func (g *getManifestCmd) run() error {
 	if err != nil {
 		return prettyError(err)
 	}
+	if res.Release == nil {
+		return fmt.Errorf("no release content returned for %q", g.release)
+	}
 	fmt.Fprintln(g.out, res.Release.Manifest)
 	return nil
 }
@@ -17,7 +17,9 @@ limitations under the License.
 package main
 
 import (
+	"bytes"
 	"io"
+	"strings"
 	"testing"
 
 	"github.com/spf13/cobra"
@@ -46,3 +48,47 @@ func TestGetCmd(t *testing.T) {
 	}
 	runReleaseCases(t, tests, cmd)
 }
+
+func TestGetNilRelease(t *testing.T) {
+	tests := []struct {
+		name string
+		run  func(client helm.Interface, out io.Writer) error
+	}{
+		{
+			name: "get",
+			run: func(client helm.Interface, out io.Writer) error {
+				return (&getCmd{release: "thomas-guide", client: client, out: out}).run()
+			},
+		},
+		{
+			name: "get hooks",
+			run: func(client helm.Interface, out io.Writer) error {
+				return (&getHooksCmd{release: "thomas-guide", client: client, out: out}).run()
+			},
+		},
+		{
+			name: "get manifest",
+			run: func(client helm.Interface, out io.Writer) error {
+				return (&getManifestCmd{release: "thomas-guide", client: client, out: out}).run()
+			},
+		},
+		{
+			name: "get values",
+			run: func(client helm.Interface, out io.Writer) error {
+				return (&getValuesCmd{release: "thomas-guide", client: client, out: out}).run()
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		err := tt.run(&nilReleaseClient{&helm.FakeClient{}}, &buf)
+		if err == nil {
+			t.Errorf("%q: expected an error for a response without a release", tt.name)
+			continue
+		}
+		if !strings.Contains(err.Error(), "no release content returned") {
+			t.Errorf("%q: unexpected error: %s", tt.name, err)
+		}
+	}
+}
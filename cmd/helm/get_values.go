@@ -78,6 +78,9 @@ func (g *getValuesCmd) run() error {
 	if err != nil {
 		return prettyError(err)
 	}
+	if res.Release == nil {
+		return fmt.Errorf("no release content returned for %q", g.release)
+	}
 
 	values, err := chartutil.ReadValues([]byte(res.Release.Config.Raw))
 	if err != nil {
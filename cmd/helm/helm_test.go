@@ -34,6 +34,7 @@ import (
 	"k8s.io/helm/pkg/helm/environment"
 	"k8s.io/helm/pkg/helm/helmpath"
 	"k8s.io/helm/pkg/proto/hapi/release"
+	"k8s.io/helm/pkg/proto/hapi/services"
 	"k8s.io/helm/pkg/repo"
 )
 
@@ -78,6 +79,15 @@ type releaseCase struct {
 	responses map[string]release.TestRun_Status
 }
 
+// nilReleaseClient is a FakeClient whose ReleaseContent succeeds without returning a release.
+type nilReleaseClient struct {
+	*helm.FakeClient
+}
+
+func (c *nilReleaseClient) ReleaseContent(rlsName string, opts ...helm.ContentOption) (*services.GetReleaseContentResponse, error) {
+	return &services.GetReleaseContentResponse{}, nil
+}
+
 // tempHelmHome sets up a Helm Home in a temp dir.
 //
 // This does not clean up the directory. You must do that yourself.
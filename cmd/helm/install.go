@@ -17,7 +17,9 @@ limitations under the License.
 package main
 
 import (
+	"archive/tar"
 	"bytes"
+	"compress/gzip"
 	"errors"
 	"fmt"
 	"io"
@@ -25,6 +27,7 @@ import (
 	"net/url"
 	"os"
 	"path/filepath"
+	"runtime"
 	"strings"
 	"text/template"
 
@@ -81,6 +84,12 @@ set for a key called 'foo', the 'newbar' value would take precedence:
 
 	$ helm install --set foo=bar --set foo=newbar ./redis
 
+By default '--values' files are merged first, then '--set', '--set-string' and
+'--set-file', so a '--set' value wins over the same key in a values file. Use
+'--merge-order' to change this precedence; sources listed later win:
+
+	$ helm install --merge-order set,set-string,set-file,values -f override.yaml --set foo=bar ./redis
+
 
 To check the generated manifests of a release without installing the chart,
 the '--debug' and '--dry-run' flags can be combined. This will still require a
@@ -127,6 +136,7 @@ type installCmd struct {
 	values         []string
 	stringValues   []string
 	fileValues     []string
+	mergeOrder     []string
 	nameTemplate   string
 	version        string
 	timeout        int64
@@ -135,6 +145,8 @@ type installCmd struct {
 	repoURL        string
 	username       string
 	password       string
+	usernameFile   string
+	passwordFile   string
 	devel          bool
 	depUp          bool
 	subNotes       bool
@@ -184,6 +196,10 @@ func newInstallCmd(c helm.Interface, out io.Writer) *cobra.Command {
 				inst.version = ">0.0.0-0"
 			}
 
+			if err := resolveCredentials(&inst.username, &inst.password, inst.usernameFile, inst.passwordFile); err != nil {
+				return err
+			}
+
 			cp, err := locateChartPath(inst.repoURL, inst.username, inst.password, args[0], inst.version, inst.verify, inst.keyring,
 				inst.certFile, inst.keyFile, inst.caFile)
 			if err != nil {
@@ -209,6 +225,7 @@ func newInstallCmd(c helm.Interface, out io.Writer) *cobra.Command {
 	f.StringArrayVar(&inst.values, "set", []string{}, "set values on the command line (can specify multiple or separate values with commas: key1=val1,key2=val2)")
 	f.StringArrayVar(&inst.stringValues, "set-string", []string{}, "set STRING values on the command line (can specify multiple or separate values with commas: key1=val1,key2=val2)")
 	f.StringArrayVar(&inst.fileValues, "set-file", []string{}, "set values from respective files specified via the command line (can specify multiple or separate values with commas: key1=path1,key2=path2)")
+	f.StringSliceVar(&inst.mergeOrder, "merge-order", defaultMergeOrder, "order in which value sources are merged, later sources taking precedence (each of values, set, set-string, set-file once)")
 	f.StringVar(&inst.nameTemplate, "name-template", "", "specify template used to name the release")
 	f.BoolVar(&inst.verify, "verify", false, "verify the package before installing it")
 	f.StringVar(&inst.keyring, "keyring", defaultKeyring(), "location of public keys used for verification")
@@ -219,6 +236,8 @@ func newInstallCmd(c helm.Interface, out io.Writer) *cobra.Command {
 	f.StringVar(&inst.repoURL, "repo", "", "chart repository url where to locate the requested chart")
 	f.StringVar(&inst.username, "username", "", "chart repository username where to locate the requested chart")
 	f.StringVar(&inst.password, "password", "", "chart repository password where to locate the requested chart")
+	f.StringVar(&inst.usernameFile, "username-file", "", "read the chart repository username from this file")
+	f.StringVar(&inst.passwordFile, "password-file", "", "read the chart repository password from this file, which must not be accessible by group or others")
 	f.StringVar(&inst.certFile, "cert-file", "", "identify HTTPS client using this SSL certificate file")
 	f.StringVar(&inst.keyFile, "key-file", "", "identify HTTPS client using this SSL key file")
 	f.StringVar(&inst.caFile, "ca-file", "", "verify certificates of HTTPS-enabled servers using this CA bundle")
@@ -240,7 +259,7 @@ func (i *installCmd) run() error {
 		i.namespace = defaultNamespace()
 	}
 
-	rawVals, err := vals(i.valueFiles, i.values, i.stringValues, i.fileValues, i.certFile, i.keyFile, i.caFile)
+	rawVals, err := vals(i.valueFiles, i.values, i.stringValues, i.fileValues, i.mergeOrder, i.certFile, i.keyFile, i.caFile)
 	if err != nil {
 		return err
 	}
@@ -262,7 +281,7 @@ func (i *installCmd) run() error {
 	// Check chart requirements to make sure all dependencies are present in /charts
 	chartRequested, err := chartutil.Load(i.chartPath)
 	if err != nil {
-		return prettyError(err)
+		return chartLoadError(i.chartPath, err)
 	}
 
 	if req, err := chartutil.LoadRequirements(chartRequested); err == nil {
@@ -286,7 +305,7 @@ func (i *installCmd) run() error {
 				// Update all dependencies which are present in /charts.
 				chartRequested, err = chartutil.Load(i.chartPath)
 				if err != nil {
-					return prettyError(err)
+					return chartLoadError(i.chartPath, err)
 				}
 			} else {
 				return prettyError(err)
@@ -355,6 +374,33 @@ func (i *installCmd) run() error {
 	return nil
 }
 
+// chartLoadError rewrites common chart loading failures into errors that tell
+// the user what is wrong with the chart and how to fix it.
+func chartLoadError(chartPath string, err error) error {
+	fi, statErr := os.Stat(chartPath)
+	if statErr != nil {
+		return prettyError(err)
+	}
+	if fi.IsDir() {
+		// chartutil.IsChartDir has already reported that no Chart.yaml exists;
+		// replace that error with guidance on where a chart directory starts.
+		if _, chartErr := os.Stat(filepath.Join(chartPath, "Chart.yaml")); os.IsNotExist(chartErr) {
+			return fmt.Errorf("%s is not a chart directory: no Chart.yaml found. Point to the chart's root directory, or use 'helm create' to scaffold a new chart", chartPath)
+		}
+		return prettyError(err)
+	}
+	switch err {
+	case io.EOF:
+		// A zero-byte archive, usually left behind by a failed download.
+		return fmt.Errorf("%s is an empty chart archive. Fetch the chart again, or rebuild it with 'helm package'", chartPath)
+	case gzip.ErrHeader, gzip.ErrChecksum, io.ErrUnexpectedEOF, tar.ErrHeader:
+		return fmt.Errorf("%s is not a valid chart archive (%s). Fetch the chart again, or rebuild it with 'helm package'", chartPath, err)
+	case chartutil.ErrChartMetadataMissing:
+		return fmt.Errorf("%s is not a valid chart archive: no Chart.yaml found at the chart root. Rebuild it with 'helm package' from the chart's root directory", chartPath)
+	}
+	return prettyError(err)
+}
+
 // Merges source and destination map, preferring values from the source map
 func mergeValues(dest map[string]interface{}, src map[string]interface{}) map[string]interface{} {
 	for k, v := range src {
@@ -382,56 +428,106 @@ func mergeValues(dest map[string]interface{}, src map[string]interface{}) map[st
 	return dest
 }
 
-// vals merges values from files specified via -f/--values and
-// directly via --set or --set-string or --set-file, marshaling them to YAML
-func vals(valueFiles valueFiles, values []string, stringValues []string, fileValues []string, CertFile, KeyFile, CAFile string) ([]byte, error) {
-	base := map[string]interface{}{}
-
-	// User specified a values files via -f/--values
-	for _, filePath := range valueFiles {
-		currentMap := map[string]interface{}{}
-
-		var bytes []byte
-		var err error
-		if strings.TrimSpace(filePath) == "-" {
-			bytes, err = ioutil.ReadAll(os.Stdin)
-		} else {
-			bytes, err = readFile(filePath, CertFile, KeyFile, CAFile)
+// defaultMergeOrder is the order in which vals merges the value sources.
+// Sources later in the order take precedence over earlier ones.
+var defaultMergeOrder = []string{"values", "set", "set-string", "set-file"}
+
+// checkMergeOrder verifies that order names every value source exactly once.
+func checkMergeOrder(order []string) error {
+	seen := map[string]bool{}
+	for _, source := range order {
+		known := false
+		for _, s := range defaultMergeOrder {
+			if source == s {
+				known = true
+				break
+			}
 		}
-
-		if err != nil {
-			return []byte{}, err
+		if !known {
+			return fmt.Errorf("invalid --merge-order: unknown value source %q (must be one of %s)", source, strings.Join(defaultMergeOrder, ", "))
 		}
-
-		if err := yaml.Unmarshal(bytes, &currentMap); err != nil {
-			return []byte{}, fmt.Errorf("failed to parse %s: %s", filePath, err)
+		if seen[source] {
+			return fmt.Errorf("invalid --merge-order: value source %q is listed more than once", source)
 		}
-		// Merge with the previous map
-		base = mergeValues(base, currentMap)
+		seen[source] = true
 	}
-
-	// User specified a value via --set
-	for _, value := range values {
-		if err := strvals.ParseInto(value, base); err != nil {
-			return []byte{}, fmt.Errorf("failed parsing --set data: %s", err)
-		}
+	if len(seen) != len(defaultMergeOrder) {
+		return fmt.Errorf("invalid --merge-order: must list each of %s exactly once", strings.Join(defaultMergeOrder, ", "))
 	}
+	return nil
+}
 
-	// User specified a value via --set-string
-	for _, value := range stringValues {
-		if err := strvals.ParseIntoString(value, base); err != nil {
-			return []byte{}, fmt.Errorf("failed parsing --set-string data: %s", err)
-		}
+// vals merges values from files specified via -f/--values and
+// directly via --set or --set-string or --set-file, marshaling them to YAML.
+//
+// The sources are merged in mergeOrder, with later sources taking precedence.
+// An empty mergeOrder uses defaultMergeOrder.
+func vals(valueFiles valueFiles, values []string, stringValues []string, fileValues []string, mergeOrder []string, CertFile, KeyFile, CAFile string) ([]byte, error) {
+	if len(mergeOrder) == 0 {
+		mergeOrder = defaultMergeOrder
+	}
+	if err := checkMergeOrder(mergeOrder); err != nil {
+		return []byte{}, err
 	}
 
-	// User specified a value via --set-file
-	for _, value := range fileValues {
-		reader := func(rs []rune) (interface{}, error) {
-			bytes, err := readFile(string(rs), CertFile, KeyFile, CAFile)
-			return string(bytes), err
-		}
-		if err := strvals.ParseIntoFile(value, base, reader); err != nil {
-			return []byte{}, fmt.Errorf("failed parsing --set-file data: %s", err)
+	base := map[string]interface{}{}
+
+	for _, source := range mergeOrder {
+		switch source {
+		case "values":
+			// User specified a values files via -f/--values
+			for _, filePath := range valueFiles {
+				currentMap := map[string]interface{}{}
+
+				var bytes []byte
+				var err error
+				if strings.TrimSpace(filePath) == "-" {
+					bytes, err = ioutil.ReadAll(os.Stdin)
+				} else {
+					bytes, err = readFile(filePath, CertFile, KeyFile, CAFile)
+				}
+
+				if err != nil {
+					return []byte{}, fmt.Errorf("failed to read values file %s: %s", filePath, unwrapPathError(err))
+				}
+
+				if err := yaml.Unmarshal(bytes, &currentMap); err != nil {
+					return []byte{}, fmt.Errorf("failed to parse %s: %s", filePath, err)
+				}
+				// Merge with the previous map
+				base = mergeValues(base, currentMap)
+			}
+
+		case "set":
+			// User specified a value via --set
+			for _, value := range values {
+				if err := strvals.ParseInto(value, base); err != nil {
+					return []byte{}, fmt.Errorf("failed parsing --set data %q: %s", value, err)
+				}
+			}
+
+		case "set-string":
+			// User specified a value via --set-string
+			for _, value := range stringValues {
+				if err := strvals.ParseIntoString(value, base); err != nil {
+					return []byte{}, fmt.Errorf("failed parsing --set-string data %q: %s", value, err)
+				}
+			}
+
+		case "set-file":
+			// User specified a value via --set-file
+			for _, value := range fileValues {
+				reader := func(rs []rune) (interface{}, error) {
+					bytes, err := readFile(string(rs), CertFile, KeyFile, CAFile)
+					if err != nil {
+						return nil, fmt.Errorf("failed to read file: %s", err)
+					}
+					return string(bytes), nil
+				}
+				if err := strvals.ParseIntoFile(value, base, reader); err != nil {
+					return []byte{}, fmt.Errorf("failed parsing --set-file data %q: %s", value, err)
+				}
+			}
 		}
 	}
 
@@ -450,6 +546,52 @@ func (i *installCmd) printRelease(rel *release.Release) {
 	}
 }
 
+// resolveCredentials sets username and password from the contents of
+// usernameFile and passwordFile, when those are given. A credential may be
+// passed either directly or through its file, but not both.
+func resolveCredentials(username, password *string, usernameFile, passwordFile string) error {
+	credentials := []struct {
+		flag  string
+		value *string
+		file  string
+	}{
+		{"username", username, usernameFile},
+		{"password", password, passwordFile},
+	}
+	for _, c := range credentials {
+		if c.file == "" {
+			continue
+		}
+		if *c.value != "" {
+			return fmt.Errorf("--%s and --%s-file cannot be used together", c.flag, c.flag)
+		}
+		v, err := readCredentialFile(c.file)
+		if err != nil {
+			return err
+		}
+		*c.value = v
+	}
+	return nil
+}
+
+// readCredentialFile returns the contents of a credential file without its
+// trailing newline. Outside of Windows, the file must not be accessible by
+// group or others.
+func readCredentialFile(path string) (string, error) {
+	fi, err := os.Stat(path)
+	if err != nil {
+		return "", err
+	}
+	if perm := fi.Mode().Perm(); runtime.GOOS != "windows" && perm&0077 != 0 {
+		return "", fmt.Errorf("credential file %s has permissions %#o, but must not be accessible by group or others (try 'chmod 600 %s')", path, perm, path)
+	}
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(string(b), "\r\n"), nil
+}
+
 // locateChartPath looks for a chart directory in known places, and returns either the full path or an error.
 //
 // This does not ensure that the chart is well-formed; only that the requested filename exists.
@@ -548,6 +690,15 @@ func defaultNamespace() string {
 	return "default"
 }
 
+// unwrapPathError returns the error underlying an *os.PathError, so that callers
+// which already name the file don't print its path twice.
+func unwrapPathError(err error) error {
+	if pe, ok := err.(*os.PathError); ok {
+		return pe.Err
+	}
+	return err
+}
+
 //readFile load a file from the local directory or a remote file with a url.
 func readFile(filePath, CertFile, KeyFile, CAFile string) ([]byte, error) {
 	u, _ := url.Parse(filePath)
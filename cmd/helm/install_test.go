@@ -17,13 +17,20 @@ limitations under the License.
 package main
 
 import (
+	"archive/tar"
+	"compress/gzip"
 	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"reflect"
 	"regexp"
+	"runtime"
 	"strings"
 	"testing"
 
 	"github.com/spf13/cobra"
+	"k8s.io/helm/pkg/chartutil"
 	"k8s.io/helm/pkg/helm"
 )
 
@@ -327,3 +334,238 @@ func TestMergeValues(t *testing.T) {
 		t.Errorf("Expected a map with different keys to merge properly with another map. Expected: %v, got %v", expectedMap, testMap)
 	}
 }
+
+func TestValsErrors(t *testing.T) {
+	tests := []struct {
+		name         string
+		valueFiles   valueFiles
+		values       []string
+		stringValues []string
+		fileValues   []string
+		caFile       string
+		expected     string
+	}{
+		{
+			name:       "missing values file",
+			valueFiles: valueFiles{"testdata/does-not-exist.yaml"},
+			expected:   "failed to read values file testdata/does-not-exist.yaml: no such file or directory",
+		},
+		{
+			name:       "remote values file with unreadable CA",
+			valueFiles: valueFiles{"https://example.com/values.yaml"},
+			caFile:     "testdata/does-not-exist-ca.pem",
+			expected:   "failed to read values file https://example.com/values.yaml: can't create TLS config",
+		},
+		{
+			name:     "malformed set expression",
+			values:   []string{"foo=bar", "baz"},
+			expected: `failed parsing --set data "baz"`,
+		},
+		{
+			name:         "malformed set-string expression",
+			stringValues: []string{"foo.=bar"},
+			expected:     `failed parsing --set-string data "foo.=bar"`,
+		},
+		{
+			name:       "missing set-file",
+			fileValues: []string{"foo=testdata/does-not-exist.txt"},
+			expected:   `failed parsing --set-file data "foo=testdata/does-not-exist.txt": failed to read file: open testdata/does-not-exist.txt`,
+		},
+	}
+
+	for _, tt := range tests {
+		_, err := vals(tt.valueFiles, tt.values, tt.stringValues, tt.fileValues, nil, "", "", tt.caFile)
+		if err == nil {
+			t.Errorf("%q: expected error, got none", tt.name)
+			continue
+		}
+		if !strings.Contains(err.Error(), tt.expected) {
+			t.Errorf("%q: expected error containing %q, got %q", tt.name, tt.expected, err)
+		}
+	}
+}
+
+func TestChartLoadError(t *testing.T) {
+	tdir, err := ioutil.TempDir("", "helm-install-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tdir)
+
+	notAChart := filepath.Join(tdir, "not-a-chart")
+	if err := os.Mkdir(notAChart, 0755); err != nil {
+		t.Fatal(err)
+	}
+	corrupt := filepath.Join(tdir, "corrupt-0.1.0.tgz")
+	if err := ioutil.WriteFile(corrupt, []byte("this is not a gzip archive"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	empty := filepath.Join(tdir, "empty-0.1.0.tgz")
+	if err := ioutil.WriteFile(empty, []byte{}, 0644); err != nil {
+		t.Fatal(err)
+	}
+	noChartYaml := filepath.Join(tdir, "nochartyaml-0.1.0.tgz")
+	if err := writeArchive(noChartYaml, "nochartyaml/values.yaml", "name: value\n"); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		path     string
+		expected string
+	}{
+		{
+			name:     "directory without Chart.yaml",
+			path:     notAChart,
+			expected: "no Chart.yaml found",
+		},
+		{
+			name:     "corrupt archive",
+			path:     corrupt,
+			expected: "is not a valid chart archive (gzip: invalid header)",
+		},
+		{
+			name:     "empty archive",
+			path:     empty,
+			expected: "is an empty chart archive",
+		},
+		{
+			name:     "archive without Chart.yaml",
+			path:     noChartYaml,
+			expected: "no Chart.yaml found at the chart root",
+		},
+	}
+
+	for _, tt := range tests {
+		_, err := chartutil.Load(tt.path)
+		if err == nil {
+			t.Fatalf("%q: expected chart load to fail", tt.name)
+		}
+		err = chartLoadError(tt.path, err)
+		if !strings.Contains(err.Error(), tt.expected) {
+			t.Errorf("%q: expected error containing %q, got %q", tt.name, tt.expected, err)
+		}
+	}
+}
+
+// writeArchive writes a gzipped tarball at path holding a single file.
+func writeArchive(path, name, content string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	zw := gzip.NewWriter(f)
+	tw := tar.NewWriter(zw)
+	if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(content))}); err != nil {
+		return err
+	}
+	if _, err := tw.Write([]byte(content)); err != nil {
+		return err
+	}
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return zw.Close()
+}
+
+func TestValsMergeOrder(t *testing.T) {
+	tdir, err := ioutil.TempDir("", "helm-install-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tdir)
+
+	valuesFile := filepath.Join(tdir, "values.yaml")
+	if err := ioutil.WriteFile(valuesFile, []byte("foo: file\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		mergeOrder []string
+		expected   string
+		err        bool
+	}{
+		{
+			name:     "default order lets --set win",
+			expected: "foo: set\n",
+		},
+		{
+			name:       "values last lets the file win",
+			mergeOrder: []string{"set", "set-string", "set-file", "values"},
+			expected:   "foo: file\n",
+		},
+		{
+			name:       "unknown source",
+			mergeOrder: []string{"values", "set", "set-string", "set-files"},
+			err:        true,
+		},
+		{
+			name:       "missing source",
+			mergeOrder: []string{"values", "set"},
+			err:        true,
+		},
+		{
+			name:       "duplicate source",
+			mergeOrder: []string{"values", "set", "set", "set-string", "set-file"},
+			err:        true,
+		},
+	}
+
+	for _, tt := range tests {
+		out, err := vals(valueFiles{valuesFile}, []string{"foo=set"}, nil, nil, tt.mergeOrder, "", "", "")
+		if (err != nil) != tt.err {
+			t.Errorf("%q: expected error %t, got %v", tt.name, tt.err, err)
+			continue
+		}
+		if !tt.err && string(out) != tt.expected {
+			t.Errorf("%q: expected %q, got %q", tt.name, tt.expected, string(out))
+		}
+	}
+}
+
+func TestResolveCredentials(t *testing.T) {
+	tdir, err := ioutil.TempDir("", "helm-install-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tdir)
+
+	writeCredential := func(name, content string, perm os.FileMode) string {
+		p := filepath.Join(tdir, name)
+		if err := ioutil.WriteFile(p, []byte(content), perm); err != nil {
+			t.Fatal(err)
+		}
+		// Chmod explicitly so the umask can't change the permissions under test.
+		if err := os.Chmod(p, perm); err != nil {
+			t.Fatal(err)
+		}
+		return p
+	}
+	usernameFile := writeCredential("username", "aeneas\n", 0600)
+	passwordFile := writeCredential("password", "s3cr3t\n", 0600)
+	openPasswordFile := writeCredential("open-password", "s3cr3t\n", 0644)
+
+	var username, password string
+	if err := resolveCredentials(&username, &password, usernameFile, passwordFile); err != nil {
+		t.Fatal(err)
+	}
+	if username != "aeneas" || password != "s3cr3t" {
+		t.Errorf("expected credentials aeneas/s3cr3t, got %s/%s", username, password)
+	}
+
+	username, password = "", "from-flag"
+	if err := resolveCredentials(&username, &password, "", passwordFile); err == nil {
+		t.Error("expected an error when both --password and --password-file are set")
+	}
+
+	if runtime.GOOS != "windows" {
+		username, password = "", ""
+		err := resolveCredentials(&username, &password, "", openPasswordFile)
+		if err == nil || !strings.Contains(err.Error(), "must not be accessible by group or others") {
+			t.Errorf("expected a permissions error for a world-readable password file, got %v", err)
+		}
+	}
+}
@@ -48,6 +48,7 @@ type lintCmd struct {
 	values     []string
 	sValues    []string
 	fValues    []string
+	mergeOrder []string
 	namespace  string
 	strict     bool
 	paths      []string
@@ -75,6 +76,7 @@ func newLintCmd(out io.Writer) *cobra.Command {
 	cmd.Flags().StringArrayVar(&l.values, "set", []string{}, "set values on the command line (can specify multiple or separate values with commas: key1=val1,key2=val2)")
 	cmd.Flags().StringArrayVar(&l.sValues, "set-string", []string{}, "set STRING values on the command line (can specify multiple or separate values with commas: key1=val1,key2=val2)")
 	cmd.Flags().StringArrayVar(&l.fValues, "set-file", []string{}, "set values from respective files specified via the command line (can specify multiple or separate values with commas: key1=path1,key2=path2)")
+	cmd.Flags().StringSliceVar(&l.mergeOrder, "merge-order", defaultMergeOrder, "order in which value sources are merged, later sources taking precedence (each of values, set, set-string, set-file once)")
 	cmd.Flags().StringVar(&l.namespace, "namespace", "default", "namespace to put the release into")
 	cmd.Flags().BoolVar(&l.strict, "strict", false, "fail on lint warnings")
 
@@ -181,45 +183,64 @@ func lintChart(path string, vals []byte, namespace string, strict bool) (support
 // Compared to the alternative func, this func lacks the parameters for tls opts - ca key, cert, and ca cert.
 // That's because this command, `lint`, is explicitly forbidden from making server connections.
 func (l *lintCmd) vals() ([]byte, error) {
-	base := map[string]interface{}{}
+	mergeOrder := l.mergeOrder
+	if len(mergeOrder) == 0 {
+		mergeOrder = defaultMergeOrder
+	}
+	if err := checkMergeOrder(mergeOrder); err != nil {
+		return []byte{}, err
+	}
 
-	// User specified a values files via -f/--values
-	for _, filePath := range l.valueFiles {
-		currentMap := map[string]interface{}{}
-		bytes, err := ioutil.ReadFile(filePath)
-		if err != nil {
-			return []byte{}, err
-		}
+	base := map[string]interface{}{}
 
-		if err := yaml.Unmarshal(bytes, &currentMap); err != nil {
-			return []byte{}, fmt.Errorf("failed to parse %s: %s", filePath, err)
-		}
-		// Merge with the previous map
-		base = mergeValues(base, currentMap)
-	}
+	for _, source := range mergeOrder {
+		switch source {
+		case "values":
+			// User specified a values files via -f/--values
+			for _, filePath := range l.valueFiles {
+				currentMap := map[string]interface{}{}
+				bytes, err := ioutil.ReadFile(filePath)
+				if err != nil {
+					return []byte{}, fmt.Errorf("failed to read values file %s: %s", filePath, unwrapPathError(err))
+				}
+
+				if err := yaml.Unmarshal(bytes, &currentMap); err != nil {
+					return []byte{}, fmt.Errorf("failed to parse %s: %s", filePath, err)
+				}
+				// Merge with the previous map
+				base = mergeValues(base, currentMap)
+			}
 
-	// User specified a value via --set
-	for _, value := range l.values {
-		if err := strvals.ParseInto(value, base); err != nil {
-			return []byte{}, fmt.Errorf("failed parsing --set data: %s", err)
-		}
-	}
+		case "set":
+			// User specified a value via --set
+			for _, value := range l.values {
+				if err := strvals.ParseInto(value, base); err != nil {
+					return []byte{}, fmt.Errorf("failed parsing --set data %q: %s", value, err)
+				}
+			}
 
-	// User specified a value via --set-string
-	for _, value := range l.sValues {
-		if err := strvals.ParseIntoString(value, base); err != nil {
-			return []byte{}, fmt.Errorf("failed parsing --set-string data: %s", err)
-		}
-	}
+		case "set-string":
+			// User specified a value via --set-string
+			for _, value := range l.sValues {
+				if err := strvals.ParseIntoString(value, base); err != nil {
+					return []byte{}, fmt.Errorf("failed parsing --set-string data %q: %s", value, err)
+				}
+			}
 
-	// User specified a value via --set-file
-	for _, value := range l.fValues {
-		reader := func(rs []rune) (interface{}, error) {
-			bytes, err := ioutil.ReadFile(string(rs))
-			return string(bytes), err
-		}
-		if err := strvals.ParseIntoFile(value, base, reader); err != nil {
-			return []byte{}, fmt.Errorf("failed parsing --set-file data: %s", err)
+		case "set-file":
+			// User specified a value via --set-file
+			for _, value := range l.fValues {
+				reader := func(rs []rune) (interface{}, error) {
+					bytes, err := ioutil.ReadFile(string(rs))
+					if err != nil {
+						return nil, fmt.Errorf("failed to read file: %s", err)
+					}
+					return string(bytes), nil
+				}
+				if err := strvals.ParseIntoFile(value, base, reader); err != nil {
+					return []byte{}, fmt.Errorf("failed parsing --set-file data %q: %s", value, err)
+				}
+			}
 		}
 	}
 
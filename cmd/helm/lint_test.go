@@ -17,6 +17,7 @@ limitations under the License.
 package main
 
 import (
+	"strings"
 	"testing"
 )
 
@@ -52,3 +53,25 @@ func TestLintChart(t *testing.T) {
 		t.Errorf("Expected a chart parsing error")
 	}
 }
+
+func TestLintValsErrors(t *testing.T) {
+	l := &lintCmd{fValues: []string{"foo=testdata/does-not-exist.txt"}}
+	_, err := l.vals()
+	if err == nil {
+		t.Fatal("expected error for a missing --set-file")
+	}
+	expected := `failed parsing --set-file data "foo=testdata/does-not-exist.txt": failed to read file: open testdata/does-not-exist.txt`
+	if !strings.Contains(err.Error(), expected) {
+		t.Errorf("expected error containing %q, got %q", expected, err)
+	}
+
+	l = &lintCmd{values: []string{"baz"}}
+	_, err = l.vals()
+	if err == nil {
+		t.Fatal("expected error for a malformed --set")
+	}
+	expected = `failed parsing --set data "baz"`
+	if !strings.Contains(err.Error(), expected) {
+		t.Errorf("expected error containing %q, got %q", expected, err)
+	}
+}
@@ -69,6 +69,7 @@ type templateCmd struct {
 	values           []string
 	stringValues     []string
 	fileValues       []string
+	mergeOrder       []string
 	nameTemplate     string
 	showNotes        bool
 	releaseName      string
@@ -101,6 +102,7 @@ func newTemplateCmd(out io.Writer) *cobra.Command {
 	f.StringArrayVar(&t.values, "set", []string{}, "set values on the command line (can specify multiple or separate values with commas: key1=val1,key2=val2)")
 	f.StringArrayVar(&t.stringValues, "set-string", []string{}, "set STRING values on the command line (can specify multiple or separate values with commas: key1=val1,key2=val2)")
 	f.StringArrayVar(&t.fileValues, "set-file", []string{}, "set values from respective files specified via the command line (can specify multiple or separate values with commas: key1=path1,key2=path2)")
+	f.StringSliceVar(&t.mergeOrder, "merge-order", defaultMergeOrder, "order in which value sources are merged, later sources taking precedence (each of values, set, set-string, set-file once)")
 	f.StringVar(&t.nameTemplate, "name-template", "", "specify template used to name the release")
 	f.StringVar(&t.kubeVersion, "kube-version", defaultKubeVersion, "kubernetes version used as Capabilities.KubeVersion.Major/Minor")
 	f.StringVar(&t.outputDir, "output-dir", "", "writes the executed templates to files in output-dir instead of stdout")
@@ -133,7 +135,7 @@ func (t *templateCmd) run(cmd *cobra.Command, args []string) error {
 		t.namespace = defaultNamespace()
 	}
 	// get combined values and create config
-	rawVals, err := vals(t.valueFiles, t.values, t.stringValues, t.fileValues, "", "", "")
+	rawVals, err := vals(t.valueFiles, t.values, t.stringValues, t.fileValues, t.mergeOrder, "", "", "")
 	if err != nil {
 		return err
 	}
@@ -154,7 +156,7 @@ func (t *templateCmd) run(cmd *cobra.Command, args []string) error {
 	// Check chart requirements to make sure all dependencies are present in /charts
 	c, err := chartutil.Load(t.chartPath)
 	if err != nil {
-		return prettyError(err)
+		return chartLoadError(t.chartPath, err)
 	}
 
 	renderOpts := renderutil.Options{
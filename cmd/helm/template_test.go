@@ -169,6 +169,12 @@ func TestTemplateCmd(t *testing.T) {
 			expectKey:   "subchart1/templates/service.yaml",
 			expectValue: "release-name: \"foobar-abc-baz\"",
 		},
+		{
+			name:        "check_not_a_chart_directory",
+			desc:        "verify a directory without Chart.yaml is reported as not a chart",
+			args:        []string{"testdata/testcharts"},
+			expectError: "is not a chart directory: no Chart.yaml found",
+		},
 		{
 			name:        "check_kube_version",
 			desc:        "verify --kube-version overrides the kubernetes version",
@@ -96,6 +96,7 @@ type upgradeCmd struct {
 	values       []string
 	stringValues []string
 	fileValues   []string
+	mergeOrder   []string
 	verify       bool
 	keyring      string
 	install      bool
@@ -109,6 +110,8 @@ type upgradeCmd struct {
 	repoURL      string
 	username     string
 	password     string
+	usernameFile string
+	passwordFile string
 	devel        bool
 	subNotes     bool
 	description  string
@@ -158,6 +161,7 @@ func newUpgradeCmd(client helm.Interface, out io.Writer) *cobra.Command {
 	f.StringArrayVar(&upgrade.values, "set", []string{}, "set values on the command line (can specify multiple or separate values with commas: key1=val1,key2=val2)")
 	f.StringArrayVar(&upgrade.stringValues, "set-string", []string{}, "set STRING values on the command line (can specify multiple or separate values with commas: key1=val1,key2=val2)")
 	f.StringArrayVar(&upgrade.fileValues, "set-file", []string{}, "set values from respective files specified via the command line (can specify multiple or separate values with commas: key1=path1,key2=path2)")
+	f.StringSliceVar(&upgrade.mergeOrder, "merge-order", defaultMergeOrder, "order in which value sources are merged, later sources taking precedence (each of values, set, set-string, set-file once)")
 	f.BoolVar(&upgrade.disableHooks, "disable-hooks", false, "disable pre/post upgrade hooks. DEPRECATED. Use no-hooks")
 	f.BoolVar(&upgrade.disableHooks, "no-hooks", false, "disable pre/post upgrade hooks")
 	f.BoolVar(&upgrade.verify, "verify", false, "verify the provenance of the chart before upgrading")
@@ -173,6 +177,8 @@ func newUpgradeCmd(client helm.Interface, out io.Writer) *cobra.Command {
 	f.StringVar(&upgrade.repoURL, "repo", "", "chart repository url where to locate the requested chart")
 	f.StringVar(&upgrade.username, "username", "", "chart repository username where to locate the requested chart")
 	f.StringVar(&upgrade.password, "password", "", "chart repository password where to locate the requested chart")
+	f.StringVar(&upgrade.usernameFile, "username-file", "", "read the chart repository username from this file")
+	f.StringVar(&upgrade.passwordFile, "password-file", "", "read the chart repository password from this file, which must not be accessible by group or others")
 	f.StringVar(&upgrade.certFile, "cert-file", "", "identify HTTPS client using this SSL certificate file")
 	f.StringVar(&upgrade.keyFile, "key-file", "", "identify HTTPS client using this SSL key file")
 	f.StringVar(&upgrade.caFile, "ca-file", "", "verify certificates of HTTPS-enabled servers using this CA bundle")
@@ -189,6 +195,10 @@ func newUpgradeCmd(client helm.Interface, out io.Writer) *cobra.Command {
 }
 
 func (u *upgradeCmd) run() error {
+	if err := resolveCredentials(&u.username, &u.password, u.usernameFile, u.passwordFile); err != nil {
+		return err
+	}
+
 	chartPath, err := locateChartPath(u.repoURL, u.username, u.password, u.chart, u.version, u.verify, u.keyring, u.certFile, u.keyFile, u.caFile)
 	if err != nil {
 		return err
@@ -232,6 +242,7 @@ func (u *upgradeCmd) run() error {
 				values:       u.values,
 				stringValues: u.stringValues,
 				fileValues:   u.fileValues,
+				mergeOrder:   u.mergeOrder,
 				namespace:    u.namespace,
 				timeout:      u.timeout,
 				wait:         u.wait,
@@ -242,7 +253,7 @@ func (u *upgradeCmd) run() error {
 		}
 	}
 
-	rawVals, err := vals(u.valueFiles, u.values, u.stringValues, u.fileValues, u.certFile, u.keyFile, u.caFile)
+	rawVals, err := vals(u.valueFiles, u.values, u.stringValues, u.fileValues, u.mergeOrder, u.certFile, u.keyFile, u.caFile)
 	if err != nil {
 		return err
 	}
@@ -257,7 +268,7 @@ func (u *upgradeCmd) run() error {
 			return fmt.Errorf("cannot load requirements: %v", err)
 		}
 	} else {
-		return prettyError(err)
+		return chartLoadError(chartPath, err)
 	}
 
 	resp, err := u.client.UpdateRelease(
@@ -91,6 +91,14 @@ func TestUpgradeCmd(t *testing.T) {
 		t.Errorf("Error loading chart with missing dependencies: %v", err)
 	}
 
+	passwordFile := filepath.Join(tmpChart, "password")
+	if err := ioutil.WriteFile(passwordFile, []byte("s3cr3t\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chmod(passwordFile, 0600); err != nil {
+		t.Fatal(err)
+	}
+
 	tests := []releaseCase{
 		{
 			name:     "upgrade a release",
@@ -171,6 +179,21 @@ func TestUpgradeCmd(t *testing.T) {
 			expected: "Release \"crazy-bunny\" has been upgraded. Happy Helming!\n",
 			rels:     []*release.Release{helm.ReleaseMock(&helm.MockReleaseOptions{Name: "crazy-bunny", Version: 2, Chart: ch2, Description: "foo"})},
 		},
+		{
+			name:     "upgrade a release with --password-file",
+			args:     []string{"funny-bunny", chartPath},
+			flags:    []string{"--password-file", passwordFile},
+			resp:     helm.ReleaseMock(&helm.MockReleaseOptions{Name: "funny-bunny", Version: 7, Chart: ch2}),
+			expected: "Release \"funny-bunny\" has been upgraded. Happy Helming!\n",
+			rels:     []*release.Release{helm.ReleaseMock(&helm.MockReleaseOptions{Name: "funny-bunny", Version: 7, Chart: ch2})},
+		},
+		{
+			name:  "upgrade a release with both --password and --password-file",
+			args:  []string{"funny-bunny", chartPath},
+			flags: []string{"--password", "s3cr3t", "--password-file", passwordFile},
+			resp:  helm.ReleaseMock(&helm.MockReleaseOptions{Name: "funny-bunny", Version: 7, Chart: ch2}),
+			err:   true,
+		},
 		{
 			name: "upgrade a release with missing dependencies",
 			args: []string{"bonkers-bunny", missingDepsPath},
@@ -88,7 +88,7 @@ func IsChartDir(dirName string) (bool, error) {
 		return false, err
 	}
 	if chartContent == nil {
-		return false, errors.New("chart metadata (Chart.yaml) missing")
+		return false, ErrChartMetadataMissing
 	}
 	if chartContent.Name == "" {
 		return false, errors.New("invalid chart (Chart.yaml): name must not be empty")
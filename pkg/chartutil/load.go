@@ -37,6 +37,9 @@ import (
 	"k8s.io/helm/pkg/sympath"
 )
 
+// ErrChartMetadataMissing indicates that a chart has no Chart.yaml.
+var ErrChartMetadataMissing = errors.New("chart metadata (Chart.yaml) missing")
+
 // Load takes a string name, tries to resolve it to a file or directory, and then loads it.
 //
 // This is the preferred way to load a chart. It will discover the chart encoding
@@ -197,7 +200,7 @@ func LoadFiles(files []*BufferedFile) (*chart.Chart, error) {
 
 	// Ensure that we got a Chart.yaml file
 	if c.Metadata == nil {
-		return c, errors.New("chart metadata (Chart.yaml) missing")
+		return c, ErrChartMetadataMissing
 	}
 	if c.Metadata.Name == "" {
 		return c, errors.New("invalid chart (Chart.yaml): name must not be empty")
@@ -201,6 +201,9 @@ icon: https://example.com/64x64.png
 	if err.Error() != "chart metadata (Chart.yaml) missing" {
 		t.Errorf("Expected chart metadata missing error, got '%s'", err.Error())
 	}
+	if err != ErrChartMetadataMissing {
+		t.Errorf("Expected ErrChartMetadataMissing, got '%s'", err.Error())
+	}
 
 	// legacy check
 	c, err = LoadFiles([]*BufferedFile{